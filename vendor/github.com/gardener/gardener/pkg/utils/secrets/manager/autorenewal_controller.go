@@ -0,0 +1,61 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+
+	ctrlmanager "sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// autoRenewalRunnable is the manager.Runnable registered by WithAutoRenewalController. It turns the one-shot renewal
+// check performed in initialize into an ongoing reconciliation by subscribing to m.backend.Watch.
+type autoRenewalRunnable struct {
+	manager *manager
+}
+
+var _ ctrlmanager.Runnable = &autoRenewalRunnable{}
+
+// Start implements manager.Runnable. It blocks until ctx is cancelled.
+func (r *autoRenewalRunnable) Start(ctx context.Context) error {
+	return r.manager.backend.Watch(ctx, r.manager.identity, r.manager.namespace, func(secret BackendSecret) {
+		mustRenew, err := r.manager.mustAutoRenewSecret(secret)
+		if err != nil {
+			r.manager.logger.Error(err, "Failed checking whether secret must be automatically renewed", "secret", secret.Name)
+			return
+		}
+
+		if !mustRenew {
+			return
+		}
+
+		name := secret.Labels[LabelKeyName]
+		r.manager.logger.Info("Preparing secret for automatic renewal", "secret", secret.Name, "issuedAt", secret.Labels[LabelKeyIssuedAtTime], "validUntil", secret.Labels[LabelKeyValidUntilTime])
+
+		r.manager.lock.Lock()
+		r.manager.lastRotationInitiationTimes[name] = unixTime(r.manager.clock.Now())
+		r.manager.lock.Unlock()
+
+		if err := r.manager.backend.Delete(ctx, r.manager.namespace, secret.Name); err != nil {
+			r.manager.logger.Error(err, "Failed deleting secret for automatic renewal", "secret", secret.Name)
+		}
+	})
+}
+
+// addAutoRenewalController registers the auto-renewal Runnable for m with mgr, so that m's managed secrets are
+// watched continuously for renewal instead of only being checked once in initialize.
+func addAutoRenewalController(mgr ctrlmanager.Manager, m *manager) error {
+	return mgr.Add(&autoRenewalRunnable{manager: m})
+}
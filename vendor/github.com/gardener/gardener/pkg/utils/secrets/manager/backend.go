@@ -0,0 +1,49 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"time"
+)
+
+// BackendSecret is a backend-agnostic representation of a secret managed by the manager: its data plus the
+// manager's bookkeeping labels (LabelKeyName, LabelKeyChecksumConfig, LabelKeyLastRotationInitiationTime, etc.) and
+// the time the secret was created, which is used to determine the newest secret for a given name.
+type BackendSecret struct {
+	Name      string
+	Labels    map[string]string
+	Data      map[string][]byte
+	CreatedAt time.Time
+}
+
+// Backend abstracts where the secrets managed by the manager are actually stored. KubernetesBackend, which stores
+// them as corev1.Secret objects exactly as before Backend was introduced, is used when New is called without
+// WithBackend.
+type Backend interface {
+	// List returns all secrets managed by the given manager identity in the given namespace.
+	List(ctx context.Context, identity, namespace string) ([]BackendSecret, error)
+	// Get returns the secret with the given name in the given namespace, or nil if it doesn't exist.
+	Get(ctx context.Context, namespace, name string) (*BackendSecret, error)
+	// Create creates the given secret in the given namespace. It is a no-op if a secret with that name already
+	// exists, since secret names are derived from a content hash of their config.
+	Create(ctx context.Context, namespace string, secret *BackendSecret) error
+	// Delete deletes the secret with the given name in the given namespace.
+	Delete(ctx context.Context, namespace, name string) error
+	// Watch calls handler whenever a secret managed by the given manager identity in the given namespace is created
+	// or changed, including once for every matching secret that already exists when Watch is called. It blocks
+	// until ctx is cancelled.
+	Watch(ctx context.Context, identity, namespace string, handler func(BackendSecret)) error
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"time"
+
+	"github.com/gardener/gardener/pkg/utils"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlhandler "sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// kubernetesBackendPollInterval is how often KubernetesBackend falls back to polling in Watch when no Cache was
+// given.
+const kubernetesBackendPollInterval = 30 * time.Second
+
+// KubernetesBackend is the default Backend. It stores secrets as corev1.Secret objects in the cluster, exactly as
+// the manager did before Backend was introduced.
+type KubernetesBackend struct {
+	Client client.Client
+	// Cache is optional. If set, Watch is informer-backed instead of falling back to polling.
+	Cache cache.Cache
+}
+
+var _ Backend = &KubernetesBackend{}
+
+// List implements Backend.
+func (b *KubernetesBackend) List(ctx context.Context, identity, namespace string) ([]BackendSecret, error) {
+	secretList := &corev1.SecretList{}
+	if err := b.Client.List(ctx, secretList, client.InNamespace(namespace), client.MatchingLabels{
+		LabelKeyManagedBy:       LabelValueSecretsManager,
+		LabelKeyManagerIdentity: identity,
+	}); err != nil {
+		return nil, err
+	}
+
+	out := make([]BackendSecret, 0, len(secretList.Items))
+	for _, secret := range secretList.Items {
+		out = append(out, backendSecretFromSecret(&secret))
+	}
+	return out, nil
+}
+
+// Get implements Backend.
+func (b *KubernetesBackend) Get(ctx context.Context, namespace, name string) (*BackendSecret, error) {
+	secret := &corev1.Secret{}
+	if err := b.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	out := backendSecretFromSecret(secret)
+	return &out, nil
+}
+
+// Create implements Backend.
+func (b *KubernetesBackend) Create(ctx context.Context, namespace string, secret *BackendSecret) error {
+	obj := Secret(metav1.ObjectMeta{Name: secret.Name, Namespace: namespace, Labels: secret.Labels}, secret.Data)
+	if err := b.Client.Create(ctx, obj); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+// Delete implements Backend.
+func (b *KubernetesBackend) Delete(ctx context.Context, namespace, name string) error {
+	obj := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	return client.IgnoreNotFound(b.Client.Delete(ctx, obj))
+}
+
+// Watch implements Backend. When Cache is set, it is informer-backed with a rate-limited workqueue so that
+// transient read errors are retried instead of dropped; otherwise it falls back to polling List every
+// kubernetesBackendPollInterval.
+func (b *KubernetesBackend) Watch(ctx context.Context, identity, namespace string, handler func(BackendSecret)) error {
+	if b.Cache == nil {
+		return b.watchByPolling(ctx, identity, namespace, handler)
+	}
+
+	informer, err := b.Cache.GetInformer(ctx, &corev1.Secret{})
+	if err != nil {
+		return err
+	}
+
+	isManaged := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return obj.GetNamespace() == namespace &&
+			obj.GetLabels()[LabelKeyManagedBy] == LabelValueSecretsManager &&
+			obj.GetLabels()[LabelKeyManagerIdentity] == identity
+	})
+
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer queue.ShutDown()
+
+	if err := (&source.Informer{Informer: informer}).Start(ctx, &ctrlhandler.EnqueueRequestForObject{}, queue, isManaged); err != nil {
+		return err
+	}
+
+	go func() {
+		<-ctx.Done()
+		queue.ShutDown()
+	}()
+
+	for {
+		item, shutdown := queue.Get()
+		if shutdown {
+			return nil
+		}
+
+		req := item.(reconcile.Request)
+
+		secret := &corev1.Secret{}
+		if err := b.Client.Get(ctx, req.NamespacedName, secret); err != nil {
+			queue.Done(item)
+			if !apierrors.IsNotFound(err) {
+				queue.AddRateLimited(item)
+			}
+			continue
+		}
+
+		handler(backendSecretFromSecret(secret))
+		queue.Done(item)
+		queue.Forget(item)
+	}
+}
+
+func (b *KubernetesBackend) watchByPolling(ctx context.Context, identity, namespace string, handler func(BackendSecret)) error {
+	seenChecksums := map[string]string{}
+
+	tick := func() error {
+		secrets, err := b.List(ctx, identity, namespace)
+		if err != nil {
+			return err
+		}
+
+		for _, secret := range secrets {
+			checksum := utils.ComputeSecretChecksum(secret.Data)
+			if seenChecksums[secret.Name] != checksum {
+				seenChecksums[secret.Name] = checksum
+				handler(secret)
+			}
+		}
+
+		return nil
+	}
+
+	if err := tick(); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(kubernetesBackendPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := tick(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func backendSecretFromSecret(secret *corev1.Secret) BackendSecret {
+	return BackendSecret{
+		Name:      secret.Name,
+		Labels:    secret.Labels,
+		Data:      secret.Data,
+		CreatedAt: secret.CreationTimestamp.Time,
+	}
+}
@@ -0,0 +1,320 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	vaultapi "github.com/hashicorp/vault/api"
+	vaultauth "github.com/hashicorp/vault/api/auth/approle"
+	vaultk8sauth "github.com/hashicorp/vault/api/auth/kubernetes"
+)
+
+// vaultBackendPollInterval is how often VaultBackend polls for changes in Watch, since Vault's KV v2 engine has no
+// native watch/notify API.
+const vaultBackendPollInterval = 30 * time.Second
+
+// VaultAuthMethod selects how VaultBackend authenticates against Vault.
+type VaultAuthMethod string
+
+const (
+	// VaultAuthMethodToken authenticates using a static token.
+	VaultAuthMethodToken VaultAuthMethod = "token"
+	// VaultAuthMethodAppRole authenticates using the AppRole auth method.
+	VaultAuthMethodAppRole VaultAuthMethod = "approle"
+	// VaultAuthMethodKubernetes authenticates using the Kubernetes auth method.
+	VaultAuthMethodKubernetes VaultAuthMethod = "kubernetes"
+)
+
+// VaultBackendConfig configures a VaultBackend.
+type VaultBackendConfig struct {
+	// Client is the Vault API client to use. Its address must already be configured.
+	Client *vaultapi.Client
+	// KVMountPath is the mount path of the KV v2 secrets engine, e.g. "secret".
+	KVMountPath string
+	// PathPrefix is prepended to "<namespace>/<name>" to compute the full KV path for a secret, e.g.
+	// "gardener".
+	PathPrefix string
+	// AuthMethod selects how to authenticate against Vault.
+	AuthMethod VaultAuthMethod
+	// Token is used when AuthMethod is VaultAuthMethodToken.
+	Token string
+	// AppRoleID and AppRoleSecretID are used when AuthMethod is VaultAuthMethodAppRole.
+	AppRoleID       string
+	AppRoleSecretID string
+	// KubernetesAuthRole and KubernetesAuthMountPath are used when AuthMethod is VaultAuthMethodKubernetes.
+	KubernetesAuthRole      string
+	KubernetesAuthMountPath string
+}
+
+// VaultBackend is a Backend that stores secret data in HashiCorp Vault's KV v2 engine instead of as corev1.Secret
+// objects. The manager's bookkeeping labels (checksum-of-config, last-rotation-initiation-time, issued-at-time,
+// valid-until-time, bundle-for) are stored as Vault custom metadata so that initialize can reconstruct
+// nameToNewestSecret without needing corev1.Secret objects.
+type VaultBackend struct {
+	client      *vaultapi.Client
+	kvMountPath string
+	pathPrefix  string
+}
+
+var _ Backend = &VaultBackend{}
+
+// NewVaultBackend authenticates against Vault using the auth method configured in cfg and returns a VaultBackend.
+// For the AppRole and Kubernetes auth methods it keeps renewing the resulting auth lease until ctx is cancelled or
+// the lease can no longer be renewed; it does not log in again after that. A static token is not renewed.
+func NewVaultBackend(ctx context.Context, cfg VaultBackendConfig) (*VaultBackend, error) {
+	secret, err := loginVault(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed logging into vault via %s auth: %w", cfg.AuthMethod, err)
+	}
+
+	if secret != nil {
+		if err := renewVaultLeaseInBackground(ctx, cfg.Client, secret); err != nil {
+			return nil, fmt.Errorf("failed starting vault lease renewer: %w", err)
+		}
+	}
+
+	return &VaultBackend{
+		client:      cfg.Client,
+		kvMountPath: cfg.KVMountPath,
+		pathPrefix:  cfg.PathPrefix,
+	}, nil
+}
+
+func loginVault(ctx context.Context, cfg VaultBackendConfig) (*vaultapi.Secret, error) {
+	switch cfg.AuthMethod {
+	case VaultAuthMethodToken, "":
+		cfg.Client.SetToken(cfg.Token)
+		return nil, nil
+
+	case VaultAuthMethodAppRole:
+		auth, err := vaultauth.NewAppRoleAuth(cfg.AppRoleID, &vaultauth.SecretID{FromString: cfg.AppRoleSecretID})
+		if err != nil {
+			return nil, err
+		}
+		return cfg.Client.Auth().Login(ctx, auth)
+
+	case VaultAuthMethodKubernetes:
+		opts := []vaultk8sauth.LoginOption{}
+		if cfg.KubernetesAuthMountPath != "" {
+			opts = append(opts, vaultk8sauth.WithMountPath(cfg.KubernetesAuthMountPath))
+		}
+		auth, err := vaultk8sauth.NewKubernetesAuth(cfg.KubernetesAuthRole, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return cfg.Client.Auth().Login(ctx, auth)
+
+	default:
+		return nil, fmt.Errorf("unknown vault auth method %q", cfg.AuthMethod)
+	}
+}
+
+// renewVaultLeaseInBackground uses Vault's Renewer API to keep renewing the auth lease of secret until ctx is
+// cancelled or the renewer stops because the lease can no longer be renewed.
+func renewVaultLeaseInBackground(ctx context.Context, client *vaultapi.Client, secret *vaultapi.Secret) error {
+	renewer, err := client.NewLifetimeWatcher(&vaultapi.LifetimeWatcherInput{Secret: secret})
+	if err != nil {
+		return err
+	}
+
+	go renewer.Start()
+	go func() {
+		defer renewer.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-renewer.DoneCh():
+				return
+			case <-renewer.RenewCh():
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (b *VaultBackend) secretPath(namespace, name string) string {
+	return fmt.Sprintf("%s/%s/%s", b.pathPrefix, namespace, name)
+}
+
+// List implements Backend.
+func (b *VaultBackend) List(ctx context.Context, identity, namespace string) ([]BackendSecret, error) {
+	listSecret, err := b.client.Logical().ListWithContext(ctx, fmt.Sprintf("%s/metadata/%s/%s", b.kvMountPath, b.pathPrefix, namespace))
+	if err != nil {
+		return nil, err
+	}
+	if listSecret == nil {
+		return nil, nil
+	}
+
+	keys, _ := listSecret.Data["keys"].([]interface{})
+	out := make([]BackendSecret, 0, len(keys))
+
+	for _, key := range keys {
+		name, ok := key.(string)
+		if !ok {
+			continue
+		}
+
+		secret, err := b.Get(ctx, namespace, name)
+		if err != nil {
+			return nil, err
+		}
+		if secret == nil || secret.Labels[LabelKeyManagerIdentity] != identity {
+			continue
+		}
+
+		out = append(out, *secret)
+	}
+
+	return out, nil
+}
+
+// Get implements Backend.
+func (b *VaultBackend) Get(ctx context.Context, namespace, name string) (*BackendSecret, error) {
+	secret, err := b.client.KVv2(b.kvMountPath).Get(ctx, b.secretPath(namespace, name))
+	if err != nil {
+		if isVaultNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	data := make(map[string][]byte, len(secret.Data))
+	for k, v := range secret.Data {
+		if s, ok := v.(string); ok {
+			data[k] = []byte(s)
+		}
+	}
+
+	labels := make(map[string]string, len(secret.Raw.Data))
+	if rawMetadata, ok := secret.Raw.Data["metadata"].(map[string]interface{}); ok {
+		if customMetadata, ok := rawMetadata["custom_metadata"].(map[string]interface{}); ok {
+			for k, v := range customMetadata {
+				if s, ok := v.(string); ok {
+					labels[k] = s
+				}
+			}
+		}
+	}
+
+	return &BackendSecret{
+		Name:      name,
+		Labels:    labels,
+		Data:      data,
+		CreatedAt: secret.CreatedTime,
+	}, nil
+}
+
+// Create implements Backend.
+func (b *VaultBackend) Create(ctx context.Context, namespace string, secret *BackendSecret) error {
+	existing, err := b.Get(ctx, namespace, secret.Name)
+	if err != nil {
+		return err
+	}
+	// A record is only fully created once it has both its data and its custom-metadata labels; a record that has
+	// data but no labels must still be completed, since initialize relies on the labels to reconstruct
+	// nameToNewestSecret.
+	if existing != nil && len(existing.Labels) > 0 {
+		return nil
+	}
+
+	// Write metadata before data: this way a failure leaves, at worst, a metadata-only record that the next Get
+	// reports as not-yet-created and Create retries from scratch, rather than a data-only record with no labels
+	// that would silently stick around forever.
+	metadata := make(map[string]interface{}, len(secret.Labels))
+	for k, v := range secret.Labels {
+		metadata[k] = v
+	}
+
+	if _, err := b.client.KVv2(b.kvMountPath).PutMetadata(ctx, b.secretPath(namespace, secret.Name), vaultapi.KVMetadataPutInput{
+		CustomMetadata: metadata,
+	}); err != nil {
+		return err
+	}
+
+	data := make(map[string]interface{}, len(secret.Data))
+	for k, v := range secret.Data {
+		data[k] = string(v)
+	}
+
+	_, err = b.client.KVv2(b.kvMountPath).Put(ctx, b.secretPath(namespace, secret.Name), data)
+	return err
+}
+
+// Delete implements Backend.
+func (b *VaultBackend) Delete(ctx context.Context, namespace, name string) error {
+	err := b.client.KVv2(b.kvMountPath).DeleteMetadata(ctx, b.secretPath(namespace, name))
+	if isVaultNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// Watch implements Backend by polling List every vaultBackendPollInterval, since Vault's KV v2 engine has no
+// native watch/notify API.
+func (b *VaultBackend) Watch(ctx context.Context, identity, namespace string, handler func(BackendSecret)) error {
+	seenRotationTimes := map[string]string{}
+
+	tick := func() error {
+		secrets, err := b.List(ctx, identity, namespace)
+		if err != nil {
+			return err
+		}
+
+		for _, secret := range secrets {
+			rotationTime := secret.Labels[LabelKeyLastRotationInitiationTime]
+			if seen, ok := seenRotationTimes[secret.Name]; !ok || seen != rotationTime {
+				seenRotationTimes[secret.Name] = rotationTime
+				handler(secret)
+			}
+		}
+
+		return nil
+	}
+
+	if err := tick(); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(vaultBackendPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := tick(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func isVaultNotFound(err error) bool {
+	if errors.Is(err, vaultapi.ErrSecretNotFound) {
+		return true
+	}
+
+	respErr, ok := err.(*vaultapi.ResponseError)
+	return ok && respErr.StatusCode == 404
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"time"
+
+	secretutils "github.com/gardener/gardener/pkg/utils/secrets"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// maintainCABundleConfigMap writes/updates a ConfigMap in the manager's namespace holding bundlePEM, the
+// PEM-concatenated set of currently trusted CA certificates for the bundle secret called name (see
+// assembleCABundlePEM). It carries the same manager identity labels as the secrets so that listSecrets-style
+// cleanup finds it, and it prunes expired CA entries by parsing each PEM block's NotAfter. It is a no-op unless
+// WithCABundleConfigMap was given. Generate calls it only for certificate configs generated with
+// SignedByCABundleFor, with name equal to that bundleFor target.
+func (m *manager) maintainCABundleConfigMap(ctx context.Context, name string, bundlePEM []byte) error {
+	if m.caBundleConfigMapName == nil {
+		return nil
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      *m.caBundleConfigMapName,
+			Namespace: m.namespace,
+			Labels: map[string]string{
+				LabelKeyManagedBy:       LabelValueSecretsManager,
+				LabelKeyManagerIdentity: m.identity,
+				LabelKeyName:            name,
+				LabelKeyBundleFor:       name,
+			},
+		},
+		Data: map[string]string{
+			secretutils.DataKeyCertificateBundle: string(pruneExpiredCertificates(bundlePEM, m.clock.Now())),
+		},
+	}
+
+	existing := &corev1.ConfigMap{}
+	if err := m.client.Get(ctx, client.ObjectKeyFromObject(configMap), existing); err != nil {
+		if apierrors.IsNotFound(err) {
+			return m.client.Create(ctx, configMap)
+		}
+		return err
+	}
+
+	existing.Labels = configMap.Labels
+	existing.Data = configMap.Data
+	return m.client.Update(ctx, existing)
+}
+
+// assembleCABundlePEM concatenates the PEM certificates that make up the currently trusted set for the CA secret
+// called name: its current certificate, its old certificate (if one is still in the store, pending expiry), and
+// its previously assembled bundle (if any). It reads from the in-memory store rather than the CA config's own
+// generated data, since the latter only contains that single certificate, not the bundle.
+func (m *manager) assembleCABundlePEM(name string) []byte {
+	secrets, ok := m.getFromStore(name)
+	if !ok {
+		return nil
+	}
+
+	var bundle bytes.Buffer
+
+	if secrets.current.obj != nil {
+		bundle.Write(secrets.current.obj.Data[secretutils.DataKeyCertificateCA])
+	}
+	if secrets.old != nil && secrets.old.obj != nil {
+		bundle.Write(secrets.old.obj.Data[secretutils.DataKeyCertificateCA])
+	}
+	if secrets.bundle != nil && secrets.bundle.obj != nil {
+		bundle.Write(secrets.bundle.obj.Data[secretutils.DataKeyCertificateBundle])
+	}
+
+	return bundle.Bytes()
+}
+
+// pruneExpiredCertificates returns the PEM blocks in bundle whose certificate is still valid at now, preserving
+// their order. Blocks that fail to parse as a certificate are dropped as well.
+func pruneExpiredCertificates(bundle []byte, now time.Time) []byte {
+	var out bytes.Buffer
+
+	rest := bundle
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil || now.After(cert.NotAfter) {
+			continue
+		}
+
+		if err := pem.Encode(&out, block); err != nil {
+			continue
+		}
+	}
+
+	return out.Bytes()
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	secretutils "github.com/gardener/gardener/pkg/utils/secrets"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// RenewalPolicy configures when a single secret becomes eligible for automatic renewal, overriding the manager-wide
+// defaults (defaultRenewFraction of validity, or defaultRenewBefore before expiry, whichever is earlier). It is
+// persisted as labels on the secret (LabelKeyRenewalFraction, LabelKeyRenewalBeforeSeconds,
+// LabelKeyRenewalMinIntervalSeconds) so that initialize can restore it from cluster state without the caller having
+// to re-pass it on every restart. Short-lived certs (hours) and long-lived CAs (years) have very different sensible
+// thresholds, which is why this is configurable per secret name rather than applied uniformly.
+type RenewalPolicy struct {
+	// RenewFraction is the fraction of the certificate's validity after which it becomes eligible for renewal.
+	// Falls back to defaultRenewFraction if zero.
+	RenewFraction float64
+	// RenewBefore is the duration before the certificate's expiry at which it becomes eligible for renewal
+	// regardless of RenewFraction. Falls back to the manager's value from WithRenewBefore, or defaultRenewBefore
+	// if that was not given, if zero.
+	RenewBefore time.Duration
+	// MinRenewalInterval, if set, suppresses renewal until at least this long has passed since the secret's last
+	// rotation, even if it would otherwise be due.
+	MinRenewalInterval time.Duration
+}
+
+// GenerateOption is a function that configures a single Generate call.
+type GenerateOption func(*generateOptions)
+
+type generateOptions struct {
+	persist       bool
+	bundleFor     *string
+	renewalPolicy *RenewalPolicy
+}
+
+// Persist marks the generated secret to be kept around (e.g. across CA rotations) instead of being cleaned up once
+// it is no longer the current secret for its name.
+func Persist() GenerateOption {
+	return func(o *generateOptions) { o.persist = true }
+}
+
+// SignedByCABundleFor marks the generated CA secret as a signer contributing to the bundle secret called name.
+// Combined with WithCABundleConfigMap, Generate also maintains a companion ConfigMap with that bundle's currently
+// trusted CA certificates.
+func SignedByCABundleFor(name string) GenerateOption {
+	return func(o *generateOptions) { o.bundleFor = &name }
+}
+
+// WithRenewalPolicy attaches policy to this secret, overriding the manager's default renewal thresholds for it.
+func WithRenewalPolicy(policy RenewalPolicy) GenerateOption {
+	return func(o *generateOptions) { o.renewalPolicy = &policy }
+}
+
+// Generate creates the secret described by config, stores it in m's internal store under config.GetName(), and
+// returns it.
+func (m *manager) Generate(ctx context.Context, config secretutils.ConfigInterface, opts ...GenerateOption) (*corev1.Secret, error) {
+	options := &generateOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	dataInterface, err := config.Generate()
+	if err != nil {
+		return nil, fmt.Errorf("failed generating data for secret %q: %w", config.GetName(), err)
+	}
+	secretData := dataInterface.SecretData()
+
+	labels, hasLifetime := maintainLifetimeLabels(config, secretData, nil)
+
+	if options.renewalPolicy != nil {
+		if labels == nil {
+			labels = make(map[string]string, 3)
+		}
+		// Only emit a label when the field was actually set; a zero value means "use the manager default", and
+		// mustAutoRenewSecret only applies that default when the label is absent.
+		if options.renewalPolicy.RenewFraction != 0 {
+			labels[LabelKeyRenewalFraction] = strconv.FormatFloat(options.renewalPolicy.RenewFraction, 'f', -1, 64)
+		}
+		if options.renewalPolicy.RenewBefore != 0 {
+			labels[LabelKeyRenewalBeforeSeconds] = strconv.FormatInt(int64(options.renewalPolicy.RenewBefore/time.Second), 10)
+		}
+		if options.renewalPolicy.MinRenewalInterval > 0 {
+			labels[LabelKeyRenewalMinIntervalSeconds] = strconv.FormatInt(int64(options.renewalPolicy.MinRenewalInterval/time.Second), 10)
+		}
+	}
+
+	var validUntilTime *string
+	if hasLifetime {
+		v := labels[LabelKeyValidUntilTime]
+		validUntilTime = &v
+	}
+
+	objectMeta, err := ObjectMeta(m.namespace, m.identity, config, false, m.getLastRotationInitiationTime(config.GetName()), validUntilTime, nil, &options.persist, options.bundleFor)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range labels {
+		objectMeta.Labels[k] = v
+	}
+
+	secret := Secret(objectMeta, secretData)
+
+	if err := m.backend.Create(ctx, m.namespace, &BackendSecret{Name: secret.Name, Labels: secret.Labels, Data: secret.Data}); err != nil {
+		return nil, fmt.Errorf("failed creating secret %q: %w", secret.Name, err)
+	}
+
+	if err := m.addToStore(config.GetName(), secret, current); err != nil {
+		return nil, err
+	}
+
+	if _, isCA := config.(*secretutils.CertificateSecretConfig); isCA && options.bundleFor != nil {
+		if err := m.maintainCABundleConfigMap(ctx, *options.bundleFor, m.assembleCABundlePEM(config.GetName())); err != nil {
+			return nil, fmt.Errorf("failed maintaining CA bundle ConfigMap for %q: %w", *options.bundleFor, err)
+		}
+	}
+
+	return secret, nil
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+
+	secretutils "github.com/gardener/gardener/pkg/utils/secrets"
+)
+
+// maintainLifetimeLabels inspects config and, if it (directly or via an embedded certificate) carries certificate
+// lifetime information, adds the issued-at-time and valid-until-time labels derived from that certificate's
+// NotBefore/NotAfter to labels. It returns the (possibly augmented) labels together with whether it added them; the
+// latter says whether this secret participates in automatic renewal at all, since mustAutoRenewSecret skips secrets
+// without these labels.
+func maintainLifetimeLabels(config secretutils.ConfigInterface, data map[string][]byte, labels map[string]string) (map[string]string, bool) {
+	var certificatePEM []byte
+
+	switch cfg := config.(type) {
+	case *secretutils.CertificateSecretConfig:
+		// A CA (no signing CA) keeps its own certificate under the CA data key.
+		dataKeyCertificate := secretutils.DataKeyCertificate
+		if cfg.SigningCA == nil {
+			dataKeyCertificate = secretutils.DataKeyCertificateCA
+		}
+		certificatePEM = data[dataKeyCertificate]
+
+	case *secretutils.ControlPlaneSecretConfig:
+		// Kubeconfig-only configs (no embedded client certificate) are never automatically rotated since there is
+		// no certificate to read a lifetime from.
+		if cfg.CertificateSecretConfig == nil {
+			return labels, false
+		}
+		certificatePEM = data[secretutils.ControlPlaneSecretDataKeyCertificatePEM(cfg.GetName())]
+
+	default:
+		return labels, false
+	}
+
+	if len(certificatePEM) == 0 {
+		return labels, false
+	}
+
+	block, _ := pem.Decode(certificatePEM)
+	if block == nil {
+		return labels, false
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return labels, false
+	}
+
+	if labels == nil {
+		labels = make(map[string]string, 2)
+	}
+
+	labels[LabelKeyIssuedAtTime] = unixTime(cert.NotBefore)
+	labels[LabelKeyValidUntilTime] = unixTime(cert.NotAfter)
+
+	return labels, true
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	secretutils "github.com/gardener/gardener/pkg/utils/secrets"
+
+	"k8s.io/apimachinery/pkg/util/clock"
+)
+
+func newTestCertificatePEM(t *testing.T, notBefore, notAfter time.Time) []byte {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed generating test key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed creating test certificate: %v", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestMaintainLifetimeLabels(t *testing.T) {
+	notBefore := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	notAfter := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	certPEM := newTestCertificatePEM(t, notBefore, notAfter)
+
+	tests := []struct {
+		name            string
+		config          secretutils.ConfigInterface
+		data            map[string][]byte
+		wantHasLifetime bool
+	}{
+		{
+			name:            "kubeconfig-only ControlPlaneSecretConfig is skipped",
+			config:          &secretutils.ControlPlaneSecretConfig{Name: "kubeconfig"},
+			data:            map[string][]byte{},
+			wantHasLifetime: false,
+		},
+		{
+			name: "ControlPlaneSecretConfig with embedded client certificate is renewed",
+			config: &secretutils.ControlPlaneSecretConfig{
+				Name:                    "kubeconfig",
+				CertificateSecretConfig: &secretutils.CertificateSecretConfig{Name: "kubeconfig"},
+			},
+			data: map[string][]byte{
+				secretutils.ControlPlaneSecretDataKeyCertificatePEM("kubeconfig"): certPEM,
+			},
+			wantHasLifetime: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			labels, hasLifetime := maintainLifetimeLabels(tt.config, tt.data, nil)
+
+			if hasLifetime != tt.wantHasLifetime {
+				t.Fatalf("hasLifetime = %v, want %v", hasLifetime, tt.wantHasLifetime)
+			}
+
+			m := &manager{clock: clock.NewFakeClock(notAfter)}
+			mustRenew, err := m.mustAutoRenewSecret(BackendSecret{Labels: labels})
+			if err != nil {
+				t.Fatalf("mustAutoRenewSecret failed: %v", err)
+			}
+			if mustRenew != tt.wantHasLifetime {
+				t.Fatalf("mustRenew = %v, want %v", mustRenew, tt.wantHasLifetime)
+			}
+
+			if !tt.wantHasLifetime {
+				if labels[LabelKeyIssuedAtTime] != "" || labels[LabelKeyValidUntilTime] != "" {
+					t.Fatalf("expected no lifetime labels, got %v", labels)
+				}
+				return
+			}
+
+			if want := unixTime(notBefore); labels[LabelKeyIssuedAtTime] != want {
+				t.Errorf("issued-at-time = %q, want %q", labels[LabelKeyIssuedAtTime], want)
+			}
+			if want := unixTime(notAfter); labels[LabelKeyValidUntilTime] != want {
+				t.Errorf("valid-until-time = %q, want %q", labels[LabelKeyValidUntilTime], want)
+			}
+		})
+	}
+}
@@ -30,6 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmanager "sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
 const (
@@ -61,6 +62,15 @@ const (
 	// data is valid. In case the data contains a certificate it is the time part of the certificate's 'not after'
 	// field.
 	LabelKeyValidUntilTime = "valid-until-time"
+	// LabelKeyRenewalFraction is a constant for a key of a label on a Secret overriding the fraction of its validity
+	// after which it becomes due for automatic renewal. See RenewalPolicy.
+	LabelKeyRenewalFraction = "renewal-fraction"
+	// LabelKeyRenewalBeforeSeconds is a constant for a key of a label on a Secret overriding the duration (in
+	// seconds) before its 'valid-until-time' at which it becomes due for automatic renewal. See RenewalPolicy.
+	LabelKeyRenewalBeforeSeconds = "renewal-before-seconds"
+	// LabelKeyRenewalMinIntervalSeconds is a constant for a key of a label on a Secret describing the minimum
+	// duration (in seconds) that must pass since its last rotation before it may be renewed again. See RenewalPolicy.
+	LabelKeyRenewalMinIntervalSeconds = "renewal-min-interval-seconds"
 
 	// LabelValueTrue is a constant for a value of a label on a Secret describing the value 'true'.
 	LabelValueTrue = "true"
@@ -68,6 +78,14 @@ const (
 	LabelValueSecretsManager = "secrets-manager"
 
 	nameSuffixBundle = "-bundle"
+
+	// defaultRenewFraction is the default fraction of a secret's validity after which it is considered due for
+	// automatic renewal.
+	defaultRenewFraction = 0.8
+	// defaultRenewBefore is the default duration before a secret's 'valid-until-time' at which it is considered due
+	// for automatic renewal, unless the secret becomes due earlier because defaultRenewFraction of its validity has
+	// already passed.
+	defaultRenewBefore = 10 * 24 * time.Hour
 )
 
 type (
@@ -77,9 +95,13 @@ type (
 		store                       secretStore
 		logger                      logr.Logger
 		client                      client.Client
+		backend                     Backend
 		namespace                   string
 		identity                    string
 		lastRotationInitiationTimes nameToUnixTime
+		renewBefore                 *time.Duration
+		autoRenewalManager          ctrlmanager.Manager
+		caBundleConfigMapName       *string
 	}
 
 	nameToUnixTime map[string]string
@@ -107,7 +129,8 @@ const (
 	bundle  secretClass = "bundle"
 )
 
-// New returns a new manager for secrets in a given namespace.
+// New returns a new manager for secrets in a given namespace. Callers that want the manager to continuously watch
+// for secrets becoming due for renewal (instead of only checking once here) must pass WithAutoRenewalController.
 func New(
 	ctx context.Context,
 	logger logr.Logger,
@@ -116,6 +139,7 @@ func New(
 	namespace string,
 	identity string,
 	secretNamesToTimes map[string]time.Time,
+	opts ...Option,
 ) (
 	Interface,
 	error,
@@ -125,40 +149,50 @@ func New(
 		clock:                       clock,
 		logger:                      logger.WithValues("namespace", namespace),
 		client:                      c,
+		backend:                     &KubernetesBackend{Client: c},
 		namespace:                   namespace,
 		identity:                    identity,
 		lastRotationInitiationTimes: make(map[string]string),
 	}
 
+	for _, opt := range opts {
+		opt(m)
+	}
+
 	if err := m.initialize(ctx, secretNamesToTimes); err != nil {
 		return nil, err
 	}
 
-	return m, nil
-}
+	if m.autoRenewalManager != nil {
+		// Wire the controller-runtime manager's cache into the default backend so that the auto-renewal Runnable
+		// gets an informer-backed workqueue instead of falling back to polling. A caller who brought their own
+		// Backend via WithBackend (together with its own Cache, if any) is left untouched.
+		if kb, ok := m.backend.(*KubernetesBackend); ok && kb.Cache == nil {
+			kb.Cache = m.autoRenewalManager.GetCache()
+		}
 
-func (m *manager) listSecrets(ctx context.Context) (*corev1.SecretList, error) {
-	secretList := &corev1.SecretList{}
-	return secretList, m.client.List(ctx, secretList, client.InNamespace(m.namespace), client.MatchingLabels{
-		LabelKeyManagedBy:       LabelValueSecretsManager,
-		LabelKeyManagerIdentity: m.identity,
-	})
+		if err := addAutoRenewalController(m.autoRenewalManager, m); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
 }
 
 func (m *manager) initialize(ctx context.Context, secretNamesToTimes map[string]time.Time) error {
-	secretList, err := m.listSecrets(ctx)
+	secrets, err := m.backend.List(ctx, m.identity, m.namespace)
 	if err != nil {
 		return err
 	}
 
-	nameToNewestSecret := make(map[string]corev1.Secret, len(secretList.Items))
+	nameToNewestSecret := make(map[string]BackendSecret, len(secrets))
 
 	// Find the newest secret in system for the respective secret names. Read their existing
 	// last-rotation-initiation-time labels and store them in our internal map.
-	for _, secret := range secretList.Items {
+	for _, secret := range secrets {
 		oldSecret, found := nameToNewestSecret[secret.Labels[LabelKeyName]]
-		if !found || oldSecret.CreationTimestamp.Time.Before(secret.CreationTimestamp.Time) {
-			nameToNewestSecret[secret.Labels[LabelKeyName]] = *secret.DeepCopy()
+		if !found || oldSecret.CreatedAt.Before(secret.CreatedAt) {
+			nameToNewestSecret[secret.Labels[LabelKeyName]] = secret
 			m.lastRotationInitiationTimes[secret.Labels[LabelKeyName]] = secret.Labels[LabelKeyLastRotationInitiationTime]
 		}
 	}
@@ -184,7 +218,7 @@ func (m *manager) initialize(ctx context.Context, secretNamesToTimes map[string]
 	return nil
 }
 
-func (m *manager) mustAutoRenewSecret(secret corev1.Secret) (bool, error) {
+func (m *manager) mustAutoRenewSecret(secret BackendSecret) (bool, error) {
 	if secret.Labels[LabelKeyIssuedAtTime] == "" || secret.Labels[LabelKeyValidUntilTime] == "" {
 		return false, nil
 	}
@@ -199,16 +233,49 @@ func (m *manager) mustAutoRenewSecret(secret corev1.Secret) (bool, error) {
 		return false, err
 	}
 
+	renewFraction := defaultRenewFraction
+	if v := secret.Labels[LabelKeyRenewalFraction]; v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			renewFraction = f
+		}
+	}
+
+	renewBefore := defaultRenewBefore
+	if m.renewBefore != nil {
+		renewBefore = *m.renewBefore
+	}
+	if v := secret.Labels[LabelKeyRenewalBeforeSeconds]; v != "" {
+		if seconds, err := strconv.ParseInt(v, 10, 64); err == nil {
+			renewBefore = time.Duration(seconds) * time.Second
+		}
+	}
+
 	var (
 		validity    = validUntilUnix - issuedAtUnix
-		renewAtUnix = issuedAtUnix + validity*80/100
+		renewAtUnix = issuedAtUnix + int64(float64(validity)*renewFraction)
 		renewAt     = time.Unix(renewAtUnix, 0).UTC()
 		validUntil  = time.Unix(validUntilUnix, 0).UTC()
 		now         = m.clock.Now().UTC()
 	)
 
-	// Renew if 80% of the validity has been reached or if the secret expires in less than 10d.
-	return now.After(renewAt) || now.After(validUntil.Add(-10*24*time.Hour)), nil
+	// Renew if renewFraction of the validity has been reached or if the secret is within its renewBefore window.
+	mustRenew := now.After(renewAt) || now.After(validUntil.Add(-renewBefore))
+
+	// A MinRenewalInterval suppresses renewal until it has passed since the last rotation, even if the secret would
+	// otherwise be due, so that short-lived certs with an aggressive renewFraction don't thrash.
+	if mustRenew {
+		if v := secret.Labels[LabelKeyRenewalMinIntervalSeconds]; v != "" {
+			minInterval, errInterval := strconv.ParseInt(v, 10, 64)
+			lastRotationUnix, errLastRotation := strconv.ParseInt(secret.Labels[LabelKeyLastRotationInitiationTime], 10, 64)
+			if errInterval == nil && errLastRotation == nil {
+				if now.Sub(time.Unix(lastRotationUnix, 0).UTC()) < time.Duration(minInterval)*time.Second {
+					mustRenew = false
+				}
+			}
+		}
+	}
+
+	return mustRenew, nil
 }
 
 func (m *manager) addToStore(name string, secret *corev1.Secret, class secretClass) error {
@@ -244,6 +311,16 @@ func (m *manager) getFromStore(name string) (secretInfos, bool) {
 	return secrets, ok
 }
 
+// getLastRotationInitiationTime returns the last rotation initiation time recorded for name, guarded by m.lock since
+// the auto-renewal Runnable started via WithAutoRenewalController writes to lastRotationInitiationTimes
+// concurrently with callers of Generate.
+func (m *manager) getLastRotationInitiationTime(name string) string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.lastRotationInitiationTimes[name]
+}
+
 func computeSecretInfo(obj *corev1.Secret) (secretInfo, error) {
 	var (
 		lastRotationStartTime int64
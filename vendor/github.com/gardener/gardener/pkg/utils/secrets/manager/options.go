@@ -0,0 +1,69 @@
+// Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/clock"
+	ctrlmanager "sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// Option is a function that configures a manager created via New.
+type Option func(*manager)
+
+// WithClock overrides the clock passed to New. Options are applied before initialize, so the overriding clock is
+// also the one used for the initial renewal check.
+func WithClock(c clock.Clock) Option {
+	return func(m *manager) {
+		m.clock = c
+	}
+}
+
+// WithRenewBefore overrides the duration before a secret's 'valid-until-time' at which it is considered due for
+// automatic renewal. If not given, defaultRenewBefore is used.
+func WithRenewBefore(d time.Duration) Option {
+	return func(m *manager) {
+		m.renewBefore = &d
+	}
+}
+
+// WithAutoRenewalController registers a background Runnable with the given controller-runtime manager that
+// watches the Secrets managed by this manager and automatically prepares those that are in their renewal window for
+// rotation, instead of only evaluating this once in New. A secret is evaluated each time the backend's Watch reports
+// it (on creation or change, and for the informer-backed KubernetesBackend also on every cache resync); an unchanged
+// secret is not re-checked at the moment its renewal window begins.
+func WithAutoRenewalController(mgr ctrlmanager.Manager) Option {
+	return func(m *manager) {
+		m.autoRenewalManager = mgr
+	}
+}
+
+// WithBackend overrides where the manager stores its secrets. If not given, KubernetesBackend is used, i.e. secrets
+// are stored as corev1.Secret objects exactly as before Backend was introduced.
+func WithBackend(backend Backend) Option {
+	return func(m *manager) {
+		m.backend = backend
+	}
+}
+
+// WithCABundleConfigMap makes Generate maintain a companion ConfigMap called name holding the PEM-concatenated set
+// of currently trusted CA certificates for CA secrets with a bundle, so that non-Go consumers which read a ca.crt
+// file from a mounted ConfigMap can participate in CA rotation without re-rolling on every secret change.
+func WithCABundleConfigMap(name string) Option {
+	return func(m *manager) {
+		m.caBundleConfigMapName = &name
+	}
+}